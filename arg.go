@@ -0,0 +1,145 @@
+package jiffy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Arg wraps a single Expression argument, giving callers a typed accessor
+// layer instead of type-switching on interface{}.  It is a thin view over
+// the same value stored in Expression.Arguments.
+type Arg struct {
+	value interface{}
+}
+
+// WrapArg wraps a raw Expression argument value in an Arg.
+func WrapArg(value interface{}) Arg {
+	return Arg{value: value}
+}
+
+// WrapArgs wraps a slice of raw Expression argument values.
+func WrapArgs(arguments []interface{}) []Arg {
+	args := make([]Arg, len(arguments))
+	for i, argument := range arguments {
+		args[i] = WrapArg(argument)
+	}
+	return args
+}
+
+// Args returns the expression's arguments as a slice of typed Arg values.
+func (expression *Expression) Args() []Arg {
+	return WrapArgs(expression.Arguments)
+}
+
+// Value returns the argument's underlying, untyped value.
+func (arg Arg) Value() interface{} {
+	return arg.value
+}
+
+// Kind reports the concrete type carried by the argument.  A value of any Go
+// type other than those produced by unmarshaling (for example an int set
+// directly in Expression.Arguments) is reported as KindNull.
+func (arg Arg) Kind() Kind {
+	switch arg.value.(type) {
+	case nil:
+		return KindNull
+	case bool:
+		return KindBool
+	case float64, json.Number:
+		return KindNumber
+	case string:
+		return KindString
+	case map[string]interface{}:
+		return KindObject
+	case *Expression:
+		return KindExpression
+	default:
+		return KindNull
+	}
+}
+
+// String returns the argument as a string, along with whether it was a string.
+func (arg Arg) String() (string, bool) {
+	value, ok := arg.value.(string)
+	return value, ok
+}
+
+// MustString returns the argument as a string, panicking if it is not one.
+func (arg Arg) MustString() string {
+	value, ok := arg.String()
+	if !ok {
+		panic(fmt.Sprintf("expected a string argument, got %v", arg.value))
+	}
+	return value
+}
+
+// Bool returns the argument as a bool, along with whether it was a bool.
+func (arg Arg) Bool() (bool, bool) {
+	value, ok := arg.value.(bool)
+	return value, ok
+}
+
+// MustBool returns the argument as a bool, panicking if it is not one.
+func (arg Arg) MustBool() bool {
+	value, ok := arg.Bool()
+	if !ok {
+		panic(fmt.Sprintf("expected a bool argument, got %v", arg.value))
+	}
+	return value
+}
+
+// Number returns the argument as a json.Number, along with whether it was a
+// number.  This works whether or not the Expression was unmarshaled with
+// UseNumber: a float64 argument is reformatted into a json.Number.
+func (arg Arg) Number() (json.Number, bool) {
+	switch value := arg.value.(type) {
+	case json.Number:
+		return value, true
+	case float64:
+		return json.Number(strconv.FormatFloat(value, 'g', -1, 64)), true
+	default:
+		return "", false
+	}
+}
+
+// MustNumber returns the argument as a json.Number, panicking if it is not a number.
+func (arg Arg) MustNumber() json.Number {
+	value, ok := arg.Number()
+	if !ok {
+		panic(fmt.Sprintf("expected a number argument, got %v", arg.value))
+	}
+	return value
+}
+
+// Object returns the argument as a map, along with whether it was a JSON object.
+func (arg Arg) Object() (map[string]interface{}, bool) {
+	value, ok := arg.value.(map[string]interface{})
+	return value, ok
+}
+
+// MustObject returns the argument as a map, panicking if it is not a JSON object.
+func (arg Arg) MustObject() map[string]interface{} {
+	value, ok := arg.Object()
+	if !ok {
+		panic(fmt.Sprintf("expected an object argument, got %v", arg.value))
+	}
+	return value
+}
+
+// Expression returns the argument as a nested *Expression, along with whether
+// it was one.
+func (arg Arg) Expression() (*Expression, bool) {
+	value, ok := arg.value.(*Expression)
+	return value, ok
+}
+
+// MustExpression returns the argument as a nested *Expression, panicking if it
+// is not one.
+func (arg Arg) MustExpression() *Expression {
+	value, ok := arg.Expression()
+	if !ok {
+		panic(fmt.Sprintf("expected an expression argument, got %v", arg.value))
+	}
+	return value
+}
@@ -0,0 +1,51 @@
+package jiffy
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArgKind(t *testing.T) {
+	expression := &Expression{}
+	err := json.Unmarshal([]byte(`["f", null, true, 42, "str", {"a": 1}, ["g"]]`), expression)
+	assert.Nil(t, err)
+
+	args := expression.Args()
+	assert.Equal(t, KindNull, args[0].Kind())
+	assert.Equal(t, KindBool, args[1].Kind())
+	assert.Equal(t, KindNumber, args[2].Kind())
+	assert.Equal(t, KindString, args[3].Kind())
+	assert.Equal(t, KindObject, args[4].Kind())
+	assert.Equal(t, KindExpression, args[5].Kind())
+}
+
+func TestArgAccessors(t *testing.T) {
+	args := WrapArgs([]interface{}{"hello", 42.0, true, map[string]interface{}{"a": 1.0}})
+
+	str, ok := args[0].String()
+	assert.True(t, ok)
+	assert.Equal(t, "hello", str)
+	assert.Equal(t, "hello", args[0].MustString())
+
+	_, ok = args[0].Number()
+	assert.False(t, ok)
+
+	num, ok := args[1].Number()
+	assert.True(t, ok)
+	assert.Equal(t, json.Number("42"), num)
+
+	b, ok := args[2].Bool()
+	assert.True(t, ok)
+	assert.True(t, b)
+
+	obj, ok := args[3].Object()
+	assert.True(t, ok)
+	assert.Equal(t, map[string]interface{}{"a": 1.0}, obj)
+}
+
+func TestArgMustPanics(t *testing.T) {
+	arg := WrapArg("not a number")
+	assert.Panics(t, func() { arg.MustNumber() })
+}
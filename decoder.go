@@ -0,0 +1,173 @@
+package jiffy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Decoder reads a stream of JSON Expressions from an input stream.  Unlike
+// unmarshaling a single Expression with json.Unmarshal, a Decoder reads
+// tokens incrementally so that deeply nested or very large expressions can
+// be processed without first materializing the whole document as
+// []interface{}.
+type Decoder struct {
+	dec       *json.Decoder
+	Validator func(string, []Arg) error
+	useNumber bool
+	started   bool
+}
+
+// NewDecoder returns a new Decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{dec: json.NewDecoder(r)}
+}
+
+// UseNumber causes the Decoder to unmarshal numeric arguments as json.Number
+// instead of float64, so that int64 IDs, large uints, and decimals that don't
+// round-trip through a 64-bit float are preserved exactly.
+func (decoder *Decoder) UseNumber() {
+	decoder.useNumber = true
+	decoder.dec.UseNumber()
+}
+
+// Next reads the next top-level Expression from the input stream.  This is
+// useful for streaming a JSON array containing many expressions, for
+// example a log or event stream.  Next returns io.EOF when there are no
+// more expressions to read.
+func (decoder *Decoder) Next() (*Expression, error) {
+	if !decoder.started {
+		token, err := decoder.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		delim, ok := token.(json.Delim)
+		if !ok || delim != '[' {
+			return nil, fmt.Errorf("expected an array, got %v", token)
+		}
+		decoder.started = true
+	}
+
+	if !decoder.dec.More() {
+		if _, err := decoder.dec.Token(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+
+	token, err := decoder.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok || delim != '[' {
+		return nil, fmt.Errorf("expected an array, got %v", token)
+	}
+
+	expression := &Expression{Validator: decoder.Validator, UseNumber: decoder.useNumber}
+	if err := decoder.decodeExpression(expression); err != nil {
+		return nil, err
+	}
+
+	return expression, nil
+}
+
+// Walk reads a single Expression from the input stream, then invokes fn once
+// for each node, children before their parent (the order in which the nodes
+// closed).  fn is first called only after the whole expression has been read
+// and validated, so an error returned by fn does not stop any input from being
+// read.  The path argument gives the location of the node within
+// the expression tree, op is the node's operator, and args are the node's
+// raw arguments (nested expressions are represented as their own
+// callback invocations rather than appearing in args).  Validation runs at
+// each node as it is closed, so an invalid node is reported before the rest
+// of the stream is read, and fn is not called for any node.
+func (decoder *Decoder) Walk(fn func(path []int, op string, args []interface{}) error) error {
+	token, err := decoder.dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok || delim != '[' {
+		return fmt.Errorf("expected an array, got %v", token)
+	}
+
+	expression := &Expression{Validator: decoder.Validator, UseNumber: decoder.useNumber}
+	if err := decoder.decodeExpression(expression); err != nil {
+		return err
+	}
+	return walkExpression(expression, nil, fn)
+}
+
+func walkExpression(expression *Expression, path []int, fn func([]int, string, []interface{}) error) error {
+	args := make([]interface{}, 0, len(expression.Arguments))
+	for i, arg := range expression.Arguments {
+		nested, ok := arg.(*Expression)
+		if !ok {
+			args = append(args, arg)
+			continue
+		}
+		if err := walkExpression(nested, append(append([]int{}, path...), i), fn); err != nil {
+			return err
+		}
+	}
+	return fn(path, expression.Operator, args)
+}
+
+// decodeExpression reads a single expression (the opening '[' token having
+// already been consumed) token-by-token, running the validator at each node
+// as it is closed so that invalid expressions fail fast.
+func (decoder *Decoder) decodeExpression(expression *Expression) error {
+	token, err := decoder.dec.Token()
+	if err != nil {
+		return err
+	}
+
+	operator, ok := token.(string)
+	if !ok {
+		return fmt.Errorf("expected a string operator, got %v", token)
+	}
+	if len(operator) == 0 {
+		return errors.New("expression must have an operator")
+	}
+
+	var arguments []interface{}
+	for decoder.dec.More() {
+		token, err := decoder.dec.Token()
+		if err != nil {
+			return err
+		}
+
+		delim, ok := token.(json.Delim)
+		if ok && delim == '[' {
+			nested := &Expression{Validator: expression.Validator, UseNumber: expression.UseNumber}
+			if err := decoder.decodeExpression(nested); err != nil {
+				return fmt.Errorf("arg %d error: %s", len(arguments), err)
+			}
+			arguments = append(arguments, nested)
+			continue
+		}
+
+		if ok && delim == '{' {
+			object, err := decodeObject(decoder.dec)
+			if err != nil {
+				return fmt.Errorf("arg %d error: %s", len(arguments), err)
+			}
+			arguments = append(arguments, object)
+			continue
+		}
+
+		arguments = append(arguments, token)
+	}
+
+	// consume the closing ']'
+	if _, err := decoder.dec.Token(); err != nil {
+		return err
+	}
+
+	expression.Operator = operator
+	expression.Arguments = arguments
+
+	return expression.Validate()
+}
@@ -0,0 +1,126 @@
+package jiffy
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoderNext(t *testing.T) {
+	stream := `[["hello", "world"], ["any", [">", 10], ["<", 20]]]`
+	decoder := NewDecoder(strings.NewReader(stream))
+
+	first, err := decoder.Next()
+	require.Nil(t, err)
+	assert.Equal(t, "hello", first.Operator)
+	assert.Equal(t, []interface{}{"world"}, first.Arguments)
+
+	second, err := decoder.Next()
+	require.Nil(t, err)
+	assert.Equal(t, "any", second.Operator)
+	require.Len(t, second.Arguments, 2)
+
+	_, err = decoder.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestDecoderNextValidates(t *testing.T) {
+	validator := func(operator string, args []Arg) error {
+		if len(args) == 0 {
+			return assert.AnError
+		}
+		return nil
+	}
+
+	stream := `[["add", 1, 2], ["oops"]]`
+	decoder := NewDecoder(strings.NewReader(stream))
+	decoder.Validator = validator
+
+	first, err := decoder.Next()
+	require.Nil(t, err)
+	assert.Equal(t, "add", first.Operator)
+
+	_, err = decoder.Next()
+	assert.NotNil(t, err)
+}
+
+func TestDecoderWalk(t *testing.T) {
+	stream := `["or", [">", 10], ["<", 20]]`
+	decoder := NewDecoder(strings.NewReader(stream))
+
+	var visited []string
+	err := decoder.Walk(func(path []int, op string, args []interface{}) error {
+		visited = append(visited, op)
+		return nil
+	})
+	require.Nil(t, err)
+	assert.Equal(t, []string{">", "<", "or"}, visited)
+}
+
+func TestDecoderWalkRunsCustomValidator(t *testing.T) {
+	validator := func(operator string, args []Arg) error {
+		if operator == "oops" {
+			return assert.AnError
+		}
+		return nil
+	}
+
+	stream := `["or", ["oops"], ["<", 20]]`
+	decoder := NewDecoder(strings.NewReader(stream))
+	decoder.Validator = validator
+
+	var visited []string
+	err := decoder.Walk(func(path []int, op string, args []interface{}) error {
+		visited = append(visited, op)
+		return nil
+	})
+	assert.EqualError(t, err, "arg 0 error: "+assert.AnError.Error())
+	assert.Empty(t, visited)
+}
+
+func TestDecoderWalkArgsExcludeNestedExpressions(t *testing.T) {
+	stream := `["or", ["void"], "literal"]`
+	decoder := NewDecoder(strings.NewReader(stream))
+
+	var topArgs []interface{}
+	err := decoder.Walk(func(path []int, op string, args []interface{}) error {
+		if op == "or" {
+			topArgs = args
+		}
+		return nil
+	})
+	require.Nil(t, err)
+	assert.Equal(t, []interface{}{"literal"}, topArgs)
+}
+
+func TestDecoderWalkPathsDoNotAlias(t *testing.T) {
+	stream := `["L0",["L1",["L2",["L3",["L4a"],["L4b"]]]]]`
+	decoder := NewDecoder(strings.NewReader(stream))
+
+	paths := make(map[string][]int)
+	err := decoder.Walk(func(path []int, op string, args []interface{}) error {
+		pathCopy := append([]int{}, path...)
+		paths[op] = pathCopy
+		return nil
+	})
+	require.Nil(t, err)
+
+	assert.Equal(t, []int{0, 0, 0, 0}, paths["L4a"])
+	assert.Equal(t, []int{0, 0, 0, 1}, paths["L4b"])
+}
+
+func TestDecoderWalkStopsOnInvalidNode(t *testing.T) {
+	stream := `["or", [], ["<", 20]]`
+	decoder := NewDecoder(strings.NewReader(stream))
+
+	var visited []string
+	err := decoder.Walk(func(path []int, op string, args []interface{}) error {
+		visited = append(visited, op)
+		return nil
+	})
+	assert.NotNil(t, err)
+	assert.Empty(t, visited)
+}
@@ -0,0 +1,25 @@
+/*
+Package eval turns parsed jiffy Expressions into executable programs.
+
+An OperatorRegistry maps operator names to Go functions.  Compile resolves an
+Expression's operators against a registry, reporting unknown or mistyped
+operators before anything runs, and returns a Program that can be evaluated
+against an environment as many times as needed.
+
+	registry := eval.NewOperatorRegistry()
+	registry.Register(">=", eval.Comparison(func(a, b float64) bool { return a >= b }))
+	registry.Register("<", eval.Comparison(func(a, b float64) bool { return a < b }))
+	registry.Register("all", eval.All())
+	registry.Register("get", eval.Get())
+
+	expression := &jiffy.Expression{}
+	json.Unmarshal([]byte(`["all", [">=", ["get","count"], 10], ["<", ["get","count"], 20]]`), expression)
+
+	program, err := eval.Compile(expression, registry)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	result, err := program.Eval(context.Background(), map[string]interface{}{"count": 15.0})
+*/
+package eval
@@ -0,0 +1,123 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tschaub/jiffy"
+)
+
+// Program is a compiled, executable Expression.  A Program can be evaluated
+// any number of times against different environments.
+type Program interface {
+	Eval(ctx context.Context, env map[string]interface{}) (interface{}, error)
+}
+
+// programFunc adapts a plain function to the Program interface.
+type programFunc func(ctx context.Context, env map[string]interface{}) (interface{}, error)
+
+func (fn programFunc) Eval(ctx context.Context, env map[string]interface{}) (interface{}, error) {
+	return fn(ctx, env)
+}
+
+// literal is a Program that always evaluates to the same value, regardless
+// of ctx or env.
+type literal struct {
+	value interface{}
+}
+
+func (lit literal) Eval(ctx context.Context, env map[string]interface{}) (interface{}, error) {
+	return lit.value, nil
+}
+
+// Operator declares how an operator compiles and runs.  Arity is the number
+// of arguments the operator requires, or -1 for a variadic operator.  Eval
+// receives the already-evaluated argument values.  Pure must be true only if
+// Eval's result depends solely on args, never on ctx or env (operators like
+// "get" that read from env must leave Pure false), so that Compile only
+// folds constant subtrees it can safely fold.
+type Operator struct {
+	Arity int
+	Pure  bool
+	Eval  func(ctx context.Context, env map[string]interface{}, args []interface{}) (interface{}, error)
+}
+
+// OperatorRegistry maps operator names to their Operator implementation.
+type OperatorRegistry struct {
+	operators map[string]Operator
+}
+
+// NewOperatorRegistry returns an empty OperatorRegistry.
+func NewOperatorRegistry() *OperatorRegistry {
+	return &OperatorRegistry{operators: make(map[string]Operator)}
+}
+
+// Register associates a name with an Operator.  Registering a name a second
+// time replaces the previous Operator.
+func (registry *OperatorRegistry) Register(name string, operator Operator) {
+	registry.operators[name] = operator
+}
+
+// Lookup returns the Operator registered for name, if any.
+func (registry *OperatorRegistry) Lookup(name string) (Operator, bool) {
+	operator, ok := registry.operators[name]
+	return operator, ok
+}
+
+// Compile resolves expression's operator (and the operators of any nested
+// expressions) against registry, returning a Program that can be evaluated.
+// Unknown operators and arity mismatches are reported here, at compile time,
+// rather than during evaluation.  A subtree whose operator is Pure and whose
+// arguments are all literals (not dependent on ctx or env) is folded into its
+// result during compilation.
+func Compile(expression *jiffy.Expression, registry *OperatorRegistry) (Program, error) {
+	operator, ok := registry.Lookup(expression.Operator)
+	if !ok {
+		return nil, fmt.Errorf("unknown operator %q", expression.Operator)
+	}
+
+	if operator.Arity >= 0 && len(expression.Arguments) != operator.Arity {
+		return nil, fmt.Errorf("operator %q expects %d argument(s), got %d", expression.Operator, operator.Arity, len(expression.Arguments))
+	}
+
+	argPrograms := make([]Program, len(expression.Arguments))
+	allLiteral := true
+	for i, argument := range expression.Arguments {
+		nested, ok := argument.(*jiffy.Expression)
+		if !ok {
+			argPrograms[i] = literal{value: argument}
+			continue
+		}
+
+		argProgram, err := Compile(nested, registry)
+		if err != nil {
+			return nil, fmt.Errorf("arg %d error: %s", i, err)
+		}
+		argPrograms[i] = argProgram
+		if _, ok := argProgram.(literal); !ok {
+			allLiteral = false
+		}
+	}
+
+	program := programFunc(func(ctx context.Context, env map[string]interface{}) (interface{}, error) {
+		args := make([]interface{}, len(argPrograms))
+		for i, argProgram := range argPrograms {
+			value, err := argProgram.Eval(ctx, env)
+			if err != nil {
+				return nil, fmt.Errorf("arg %d error: %s", i, err)
+			}
+			args[i] = value
+		}
+		return operator.Eval(ctx, env, args)
+	})
+
+	if !allLiteral || !operator.Pure {
+		return program, nil
+	}
+
+	value, err := program.Eval(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return literal{value: value}, nil
+}
@@ -0,0 +1,102 @@
+package eval_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tschaub/jiffy"
+	"github.com/tschaub/jiffy/eval"
+)
+
+func compile(t *testing.T, str string, registry *eval.OperatorRegistry) eval.Program {
+	t.Helper()
+	expression := &jiffy.Expression{}
+	require.Nil(t, json.Unmarshal([]byte(str), expression))
+
+	program, err := eval.Compile(expression, registry)
+	require.Nil(t, err)
+	return program
+}
+
+func TestEvalRangeExample(t *testing.T) {
+	registry := eval.NewStandardRegistry()
+	program := compile(t, `["all", [">=", ["get", "count"], 10], ["<", ["get", "count"], 20]]`, registry)
+
+	result, err := program.Eval(context.Background(), map[string]interface{}{"count": 15.0})
+	require.Nil(t, err)
+	assert.Equal(t, true, result)
+
+	result, err = program.Eval(context.Background(), map[string]interface{}{"count": 25.0})
+	require.Nil(t, err)
+	assert.Equal(t, false, result)
+}
+
+func TestEvalArithmetic(t *testing.T) {
+	registry := eval.NewStandardRegistry()
+	program := compile(t, `["+", ["*", 2, 3], 4]`, registry)
+
+	result, err := program.Eval(context.Background(), nil)
+	require.Nil(t, err)
+	assert.Equal(t, 10.0, result)
+}
+
+func TestCompileUnknownOperator(t *testing.T) {
+	registry := eval.NewStandardRegistry()
+	expression := &jiffy.Expression{}
+	require.Nil(t, json.Unmarshal([]byte(`["oops", 1]`), expression))
+
+	_, err := eval.Compile(expression, registry)
+	assert.EqualError(t, err, `unknown operator "oops"`)
+}
+
+func TestCompileArityMismatch(t *testing.T) {
+	registry := eval.NewStandardRegistry()
+	expression := &jiffy.Expression{}
+	require.Nil(t, json.Unmarshal([]byte(`["!", true, false]`), expression))
+
+	_, err := eval.Compile(expression, registry)
+	assert.EqualError(t, err, `operator "!" expects 1 argument(s), got 2`)
+}
+
+func TestEvalGetMissingProperty(t *testing.T) {
+	registry := eval.NewStandardRegistry()
+	program := compile(t, `["get", "missing"]`, registry)
+
+	_, err := program.Eval(context.Background(), map[string]interface{}{})
+	assert.EqualError(t, err, `no "missing" property in environment`)
+}
+
+func TestCompileFoldsConstantSubtrees(t *testing.T) {
+	registry := eval.NewStandardRegistry()
+	program := compile(t, `[">", ["+", 1, 1], 1]`, registry)
+
+	// the "get" operator reads the environment, so it must never be folded
+	getProgram := compile(t, `["get", "count"]`, registry)
+
+	result, err := program.Eval(context.Background(), nil)
+	require.Nil(t, err)
+	assert.Equal(t, true, result)
+
+	_, err = getProgram.Eval(context.Background(), map[string]interface{}{"count": 1.0})
+	require.Nil(t, err)
+
+	// a folded Pure operator runs once, at compile time, and never again
+	calls := 0
+	registry.Register("counted", eval.Operator{
+		Arity: 0,
+		Pure:  true,
+		Eval: func(ctx context.Context, env map[string]interface{}, args []interface{}) (interface{}, error) {
+			calls++
+			return true, nil
+		},
+	})
+	counted := compile(t, `["counted"]`, registry)
+	assert.Equal(t, 1, calls)
+
+	_, err = counted.Eval(context.Background(), nil)
+	require.Nil(t, err)
+	assert.Equal(t, 1, calls)
+}
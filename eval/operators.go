@@ -0,0 +1,179 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// numberOf converts an already-evaluated argument to a float64, accepting
+// both float64 and json.Number (Expression arguments may be either,
+// depending on whether the source was unmarshaled with UseNumber).
+func numberOf(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case json.Number:
+		return v.Float64()
+	default:
+		return 0, fmt.Errorf("expected a number, got %v", value)
+	}
+}
+
+// Numeric2 builds an Operator for a binary arithmetic operator, such as "+"
+// or "*", that takes two numbers and returns a number.
+func Numeric2(fn func(a, b float64) float64) Operator {
+	return Operator{
+		Arity: 2,
+		Pure:  true,
+		Eval: func(ctx context.Context, env map[string]interface{}, args []interface{}) (interface{}, error) {
+			a, err := numberOf(args[0])
+			if err != nil {
+				return nil, err
+			}
+			b, err := numberOf(args[1])
+			if err != nil {
+				return nil, err
+			}
+			return fn(a, b), nil
+		},
+	}
+}
+
+// Comparison builds an Operator for a binary comparison operator, such as
+// ">=" or "<", that takes two numbers and returns a bool.
+func Comparison(fn func(a, b float64) bool) Operator {
+	return Operator{
+		Arity: 2,
+		Pure:  true,
+		Eval: func(ctx context.Context, env map[string]interface{}, args []interface{}) (interface{}, error) {
+			a, err := numberOf(args[0])
+			if err != nil {
+				return nil, err
+			}
+			b, err := numberOf(args[1])
+			if err != nil {
+				return nil, err
+			}
+			return fn(a, b), nil
+		},
+	}
+}
+
+// Equals builds an Operator for "==" and "!=" style operators that compare
+// two arguments of any kind for equality.
+func Equals(negate bool) Operator {
+	return Operator{
+		Arity: 2,
+		Pure:  true,
+		Eval: func(ctx context.Context, env map[string]interface{}, args []interface{}) (interface{}, error) {
+			equal := reflect.DeepEqual(args[0], args[1])
+			if negate {
+				return !equal, nil
+			}
+			return equal, nil
+		},
+	}
+}
+
+// Not builds the "!" Operator: a unary logical negation.
+func Not() Operator {
+	return Operator{
+		Arity: 1,
+		Pure:  true,
+		Eval: func(ctx context.Context, env map[string]interface{}, args []interface{}) (interface{}, error) {
+			value, ok := args[0].(bool)
+			if !ok {
+				return nil, fmt.Errorf("expected a bool, got %v", args[0])
+			}
+			return !value, nil
+		},
+	}
+}
+
+// All builds the "all" Operator: a variadic logical AND over bool arguments.
+func All() Operator {
+	return Operator{
+		Arity: -1,
+		Pure:  true,
+		Eval: func(ctx context.Context, env map[string]interface{}, args []interface{}) (interface{}, error) {
+			for _, arg := range args {
+				value, ok := arg.(bool)
+				if !ok {
+					return nil, fmt.Errorf("expected a bool, got %v", arg)
+				}
+				if !value {
+					return false, nil
+				}
+			}
+			return true, nil
+		},
+	}
+}
+
+// Any builds the "any" Operator: a variadic logical OR over bool arguments.
+func Any() Operator {
+	return Operator{
+		Arity: -1,
+		Pure:  true,
+		Eval: func(ctx context.Context, env map[string]interface{}, args []interface{}) (interface{}, error) {
+			for _, arg := range args {
+				value, ok := arg.(bool)
+				if !ok {
+					return nil, fmt.Errorf("expected a bool, got %v", arg)
+				}
+				if value {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+	}
+}
+
+// Get builds the "get" Operator: a unary property accessor that reads a
+// named value out of the environment passed to Program.Eval.
+func Get() Operator {
+	return Operator{
+		Arity: 1,
+		Eval: func(ctx context.Context, env map[string]interface{}, args []interface{}) (interface{}, error) {
+			name, ok := args[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a string property name, got %v", args[0])
+			}
+			value, ok := env[name]
+			if !ok {
+				return nil, fmt.Errorf("no %q property in environment", name)
+			}
+			return value, nil
+		},
+	}
+}
+
+// NewStandardRegistry returns an OperatorRegistry populated with the
+// package's built-in operators: logic (all, any, !), comparison
+// (==, !=, >, >=, <, <=), arithmetic (+, -, *, /), and property access (get).
+func NewStandardRegistry() *OperatorRegistry {
+	registry := NewOperatorRegistry()
+
+	registry.Register("all", All())
+	registry.Register("any", Any())
+	registry.Register("!", Not())
+
+	registry.Register("==", Equals(false))
+	registry.Register("!=", Equals(true))
+	registry.Register(">", Comparison(func(a, b float64) bool { return a > b }))
+	registry.Register(">=", Comparison(func(a, b float64) bool { return a >= b }))
+	registry.Register("<", Comparison(func(a, b float64) bool { return a < b }))
+	registry.Register("<=", Comparison(func(a, b float64) bool { return a <= b }))
+
+	registry.Register("+", Numeric2(func(a, b float64) float64 { return a + b }))
+	registry.Register("-", Numeric2(func(a, b float64) float64 { return a - b }))
+	registry.Register("*", Numeric2(func(a, b float64) float64 { return a * b }))
+	registry.Register("/", Numeric2(func(a, b float64) float64 { return a / b }))
+
+	registry.Register("get", Get())
+
+	return registry
+}
@@ -59,15 +59,15 @@ func Example_nested() {
 func Example_validation() {
 	// a custom validator for validating a "+" operator
 	expression := &jiffy.Expression{
-		Validator: func(operator string, arguments []interface{}) error {
+		Validator: func(operator string, args []jiffy.Arg) error {
 			switch operator {
 			case "+":
-				if len(arguments) != 2 {
-					return fmt.Errorf("the + operator takes two arguments, got %d", len(arguments))
+				if len(args) != 2 {
+					return fmt.Errorf("the + operator takes two arguments, got %d", len(args))
 				}
-				for i, v := range arguments {
-					if _, ok := v.(float64); !ok {
-						return fmt.Errorf("expected number for argument %d, got %#v", i, v)
+				for i, arg := range args {
+					if _, ok := arg.Number(); !ok {
+						return fmt.Errorf("expected number for argument %d, got %#v", i, arg.Value())
 					}
 				}
 				return nil
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 )
 
 // Expression represents a JSON Expression.  An expression can be populated by
@@ -12,11 +13,18 @@ import (
 // unmarshaling validates that JSON conforms to the JSON Expression grammar.  An
 // expression can be given a custom Validator function that will be called during
 // unmarshalling.  The Validator function will be called with the expression's operator
-// and arguments.
+// and a typed view of its arguments, so it doesn't have to repeat the same type
+// assertions every Expression's Validator needs.
 type Expression struct {
 	Operator  string
 	Arguments []interface{}
-	Validator func(string, []interface{}) error // called with operator and arguments
+	Validator func(string, []Arg) error // called with operator and arguments
+
+	// UseNumber causes numeric arguments to be unmarshaled as json.Number instead of
+	// float64, so that int64 IDs, large uints, and decimals that don't round-trip
+	// through a 64-bit float are preserved exactly.  MarshalJSON writes json.Number
+	// arguments back out verbatim.
+	UseNumber bool
 }
 
 // Validate determines if an expression is valid.  The only built-in requirement for validation
@@ -27,7 +35,7 @@ func (expression *Expression) Validate() error {
 		return errors.New("zero length operator name")
 	}
 	if expression.Validator != nil {
-		return expression.Validator(expression.Operator, expression.Arguments)
+		return expression.Validator(expression.Operator, expression.Args())
 	}
 	return nil
 }
@@ -69,56 +77,174 @@ func (expression *Expression) MarshalJSON() ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
+// SyntaxError reports a problem with the shape of JSON Expression source, along with
+// the position of the offending token.  Offset follows the convention used by
+// encoding/json.SyntaxError: it is the offset of the first byte after the token that
+// triggered the error.  Line and Col give the 1-indexed line and column for that same
+// offset.  Path identifies the offending node by argument index, starting from the
+// root expression.
+type SyntaxError struct {
+	Msg    string
+	Offset int64
+	Line   int
+	Col    int
+	Path   []int
+}
+
+func (err *SyntaxError) Error() string {
+	return err.Msg
+}
+
+// newSyntaxError builds a SyntaxError for the byte offset dec has most recently
+// consumed from data, attributing it to the given path within the expression tree.
+// The resulting Msg reads "<prefix> at line L col C<suffix>", so a suffix like
+// ", got 42" still comes after the location rather than before it.
+func newSyntaxError(dec *json.Decoder, data []byte, path []int, prefix, suffix string) *SyntaxError {
+	offset := dec.InputOffset()
+	line, col := position(data, offset)
+	return &SyntaxError{
+		Msg:    fmt.Sprintf("%s at line %d col %d%s", prefix, line, col, suffix),
+		Offset: offset,
+		Line:   line,
+		Col:    col,
+		Path:   path,
+	}
+}
+
+// position converts a byte offset into a 1-indexed line and column.
+func position(data []byte, offset int64) (line, col int) {
+	line = 1
+	col = 1
+	limit := offset
+	if limit > int64(len(data)) {
+		limit = int64(len(data))
+	}
+	for i := int64(0); i < limit; i++ {
+		if data[i] == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return line, col
+}
+
 // UnmarshalJSON creates an expression from JSON.  If the expression has a
 // custom Validator function, this function will be called with the operator
 // and arguments during unmarshalling.  Any nested expressions will acquire
-// the same Validator function and must pass the same validation.
+// the same Validator function and must pass the same validation.  Problems
+// with the shape of the source JSON are reported as a *SyntaxError carrying
+// the line and column of the offending token.
 func (expression *Expression) UnmarshalJSON(data []byte) error {
-	var parts []interface{}
-	if partsErr := json.Unmarshal(data, &parts); partsErr != nil {
-		return partsErr
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if expression.UseNumber {
+		dec.UseNumber()
 	}
 
-	return fromParts(parts, expression)
-}
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok || delim != '[' {
+		return newSyntaxError(dec, data, nil, "expected a JSON array", fmt.Sprintf(", got %v", token))
+	}
 
-func getOperator(parts []interface{}) (string, error) {
-	if len(parts) == 0 {
-		return "", errors.New("expression must have an operator")
+	if err := fromTokens(dec, data, expression, nil); err != nil {
+		return err
 	}
 
-	opInterface := parts[0]
-	operator, ok := opInterface.(string)
-	if !ok {
-		return "", fmt.Errorf("expected a string operator, got %v", opInterface)
+	if _, err := dec.Token(); err != io.EOF {
+		return newSyntaxError(dec, data, nil, "unexpected data after expression", "")
 	}
 
-	return operator, nil
+	return nil
 }
 
-func fromParts(parts []interface{}, expression *Expression) error {
-	operator, opErr := getOperator(parts)
-	if opErr != nil {
-		return opErr
+// fromTokens reads an expression's operator and arguments from dec, with the
+// opening '[' token already consumed.  data and path are threaded through so that
+// any SyntaxError can be attributed to a line, column, and position in the tree.
+func fromTokens(dec *json.Decoder, data []byte, expression *Expression, path []int) error {
+	opToken, err := dec.Token()
+	if err != nil {
+		return err
 	}
 
-	arguments := parts[1:]
-	for i, arg := range arguments {
-		nestedParts, ok := arg.([]interface{})
-		if ok {
-			nestedExpression := &Expression{
-				Validator: expression.Validator,
+	operator, ok := opToken.(string)
+	if !ok {
+		return newSyntaxError(dec, data, path, "expected a string operator", fmt.Sprintf(", got %v", opToken))
+	}
+
+	var arguments []interface{}
+	for dec.More() {
+		argPath := append(append([]int{}, path...), len(arguments))
+
+		argToken, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		argDelim, isDelim := argToken.(json.Delim)
+		switch {
+		case isDelim && argDelim == '[':
+			nested := &Expression{Validator: expression.Validator, UseNumber: expression.UseNumber}
+			if nestedErr := fromTokens(dec, data, nested, argPath); nestedErr != nil {
+				var syntaxErr *SyntaxError
+				if errors.As(nestedErr, &syntaxErr) {
+					return syntaxErr
+				}
+				return fmt.Errorf("arg %d error: %s", len(arguments), nestedErr)
 			}
-			nestedErr := fromParts(nestedParts, nestedExpression)
-			if nestedErr != nil {
-				return fmt.Errorf("arg %d error: %s", i, nestedErr)
+			arguments = append(arguments, nested)
+
+		case isDelim && argDelim == '{':
+			object, objErr := decodeObject(dec)
+			if objErr != nil {
+				return objErr
 			}
-			arguments[i] = nestedExpression
+			arguments = append(arguments, object)
+
+		default:
+			arguments = append(arguments, argToken)
 		}
 	}
 
+	// consume the closing ']'
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
 	expression.Operator = operator
 	expression.Arguments = arguments
 
 	return expression.Validate()
 }
+
+// decodeObject reads a JSON object argument, given that the opening '{' token has
+// already been consumed from dec.
+func decodeObject(dec *json.Decoder) (map[string]interface{}, error) {
+	object := make(map[string]interface{})
+	for dec.More() {
+		keyToken, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := keyToken.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string key, got %v", keyToken)
+		}
+
+		var value interface{}
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		object[key] = value
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	return object, nil
+}
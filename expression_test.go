@@ -45,19 +45,19 @@ var cases = []TestCase{
 	{
 		name: "bad operator type (number)",
 		str:  `[42, "oops"]`,
-		err:  errors.New("expected a string operator, got 42"),
+		err:  errors.New("expected a string operator at line 1 col 4, got 42"),
 	},
 
 	{
 		name: "bad operator type (boolean)",
 		str:  `[true, "oops"]`,
-		err:  errors.New("expected a string operator, got true"),
+		err:  errors.New("expected a string operator at line 1 col 6, got true"),
 	},
 
 	{
 		name: "bad operator type (null)",
 		str:  `[null, "oops"]`,
-		err:  errors.New("expected a string operator, got <nil>"),
+		err:  errors.New("expected a string operator at line 1 col 6, got <nil>"),
 	},
 
 	{
@@ -136,15 +136,15 @@ var cases = []TestCase{
 		exp: &Expression{
 			Operator:  "pass",
 			Arguments: []interface{}{42.0},
-			Validator: func(operator string, arguments []interface{}) error {
+			Validator: func(operator string, args []Arg) error {
 				if operator != "pass" {
 					return fmt.Errorf("unexpected operator passed to validator '%s'", operator)
 				}
-				if len(arguments) != 1 {
-					return fmt.Errorf("unexpected arguments passed to validator %v", arguments)
+				if len(args) != 1 {
+					return fmt.Errorf("unexpected arguments passed to validator %v", args)
 				}
-				if arguments[0].(float64) != 42 {
-					return fmt.Errorf("unexpected arguments passed to validator %v", arguments)
+				if number, ok := args[0].Number(); !ok || number != "42" {
+					return fmt.Errorf("unexpected arguments passed to validator %v", args)
 				}
 				return nil
 			},
@@ -157,7 +157,7 @@ var cases = []TestCase{
 		exp: &Expression{
 			Operator:  "fail",
 			Arguments: []interface{}{42.0},
-			Validator: func(operator string, arguments []interface{}) error {
+			Validator: func(operator string, args []Arg) error {
 				return errors.New("fail validator")
 			},
 		},
@@ -241,14 +241,14 @@ func TestValidate(t *testing.T) {
 }
 
 func TestCustomValidator(t *testing.T) {
-	validator := func(operator string, arguments []interface{}) error {
+	validator := func(operator string, args []Arg) error {
 		if operator == "void" {
-			if len(arguments) > 0 {
+			if len(args) > 0 {
 				return errors.New("expected no arguments for void")
 			}
 			return nil
 		}
-		if len(arguments) == 0 {
+		if len(args) == 0 {
 			return errors.New("expected some arguments")
 		}
 		return nil
@@ -294,14 +294,14 @@ func TestCustomValidator(t *testing.T) {
 }
 
 func TestCustomValidatorUnmarshal(t *testing.T) {
-	validator := func(operator string, arguments []interface{}) error {
+	validator := func(operator string, args []Arg) error {
 		if operator == "void" {
-			if len(arguments) > 0 {
+			if len(args) > 0 {
 				return errors.New("expected no arguments for void")
 			}
 			return nil
 		}
-		if len(arguments) == 0 {
+		if len(args) == 0 {
 			return errors.New("expected some arguments")
 		}
 		return nil
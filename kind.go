@@ -0,0 +1,44 @@
+package jiffy
+
+// Kind identifies the concrete type carried by an Arg.
+type Kind int
+
+const (
+	// KindNull represents a JSON null argument.
+	KindNull Kind = iota
+
+	// KindBool represents a JSON boolean argument.
+	KindBool
+
+	// KindNumber represents a JSON number argument.
+	KindNumber
+
+	// KindString represents a JSON string argument.
+	KindString
+
+	// KindObject represents a JSON object argument.
+	KindObject
+
+	// KindExpression represents a nested JSON Expression argument.
+	KindExpression
+)
+
+// String returns a human readable name for the kind.
+func (kind Kind) String() string {
+	switch kind {
+	case KindNull:
+		return "null"
+	case KindBool:
+		return "bool"
+	case KindNumber:
+		return "number"
+	case KindString:
+		return "string"
+	case KindObject:
+		return "object"
+	case KindExpression:
+		return "expression"
+	default:
+		return "unknown"
+	}
+}
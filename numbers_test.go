@@ -0,0 +1,41 @@
+package jiffy
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseNumberRoundTrip(t *testing.T) {
+	cases := []string{
+		`["id", 9007199254740993]`,
+		`["scale", 1.0000000000000002]`,
+	}
+
+	for _, str := range cases {
+		expression := &Expression{UseNumber: true}
+		err := json.Unmarshal([]byte(str), expression)
+		require.Nil(t, err)
+		require.Len(t, expression.Arguments, 1)
+
+		number, ok := expression.Arguments[0].(json.Number)
+		require.True(t, ok, "expected a json.Number, got %T", expression.Arguments[0])
+
+		out, err := expression.MarshalJSON()
+		require.Nil(t, err)
+		assert.JSONEq(t, str, string(out))
+		assert.Contains(t, string(out), number.String())
+	}
+}
+
+func TestWithoutUseNumberLosesPrecision(t *testing.T) {
+	expression := &Expression{}
+	err := json.Unmarshal([]byte(`["id", 9007199254740993]`), expression)
+	require.Nil(t, err)
+
+	out, err := expression.MarshalJSON()
+	require.Nil(t, err)
+	assert.NotContains(t, string(out), "9007199254740993")
+}
@@ -0,0 +1,23 @@
+package jiffy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyntaxErrorPosition(t *testing.T) {
+	str := "[\"or\",\n[\"void\"],\n[42]]"
+	expression := &Expression{}
+
+	err := expression.UnmarshalJSON([]byte(str))
+	require.NotNil(t, err)
+
+	var syntaxErr *SyntaxError
+	require.True(t, errors.As(err, &syntaxErr))
+	assert.Equal(t, 3, syntaxErr.Line)
+	assert.Equal(t, []int{1}, syntaxErr.Path)
+	assert.Equal(t, "expected a string operator at line 3 col 4, got 42", syntaxErr.Error())
+}
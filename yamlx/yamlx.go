@@ -0,0 +1,47 @@
+/*
+Package yamlx lets JSON Expressions be authored as YAML.  YAML is converted to JSON
+(using the ghodss/yaml round-trip technique) before being handed to
+Expression.UnmarshalJSON, so Validator functions and nested-expression handling work
+exactly as they do for JSON input.  JSON remains the canonical wire form; YAML is only
+a more readable authoring format, for example the "all" expression from the jiffy
+package doc could be written in YAML's flow style as:
+
+	[all, [">=", [get, count], 10], ["<", [get, count], 20]]
+*/
+package yamlx
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/tschaub/jiffy"
+)
+
+// FromYAML parses YAML encoded data into an Expression.  The YAML is first
+// converted to JSON, then unmarshaled with expression.UnmarshalJSON, so any
+// Validator already set on expression runs as it would for JSON input.
+func FromYAML(data []byte, expression *jiffy.Expression) error {
+	jsonBytes, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return fmt.Errorf("failed to convert YAML to JSON: %s", err)
+	}
+
+	return expression.UnmarshalJSON(jsonBytes)
+}
+
+// ToYAML returns the YAML encoding of an expression.  The expression is first
+// marshaled to JSON with expression.MarshalJSON, so validation runs the same
+// way it does for JSON output, and the JSON is then converted to YAML.
+func ToYAML(expression *jiffy.Expression) ([]byte, error) {
+	jsonBytes, err := expression.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	yamlBytes, err := yaml.JSONToYAML(jsonBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert JSON to YAML: %s", err)
+	}
+
+	return yamlBytes, nil
+}
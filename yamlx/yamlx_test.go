@@ -0,0 +1,65 @@
+package yamlx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tschaub/jiffy"
+	"github.com/tschaub/jiffy/yamlx"
+)
+
+func TestFromYAML(t *testing.T) {
+	str := `
+- all
+- - ">="
+  - - get
+    - count
+  - 10
+- - "<"
+  - - get
+    - count
+  - 20
+`
+	expression := &jiffy.Expression{}
+	err := yamlx.FromYAML([]byte(str), expression)
+	require.Nil(t, err)
+
+	assert.Equal(t, "all", expression.Operator)
+	require.Len(t, expression.Arguments, 2)
+
+	first, ok := expression.Arguments[0].(*jiffy.Expression)
+	require.True(t, ok)
+	assert.Equal(t, ">=", first.Operator)
+}
+
+func TestFromYAMLRunsValidator(t *testing.T) {
+	validator := func(operator string, args []jiffy.Arg) error {
+		if len(args) == 0 {
+			return assert.AnError
+		}
+		return nil
+	}
+
+	str := "- oops"
+	expression := &jiffy.Expression{Validator: validator}
+	err := yamlx.FromYAML([]byte(str), expression)
+	assert.Equal(t, assert.AnError, err)
+}
+
+func TestToYAML(t *testing.T) {
+	expression := &jiffy.Expression{
+		Operator:  "get",
+		Arguments: []interface{}{"count"},
+	}
+
+	out, err := yamlx.ToYAML(expression)
+	require.Nil(t, err)
+	assert.Equal(t, "- get\n- count\n", string(out))
+}
+
+func TestToYAMLValidates(t *testing.T) {
+	expression := &jiffy.Expression{}
+	_, err := yamlx.ToYAML(expression)
+	assert.EqualError(t, err, "zero length operator name")
+}